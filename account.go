@@ -1,17 +1,42 @@
 package gondole
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"strconv"
 
 	"github.com/sendgrid/rest"
 )
 
+// AccountID identifies an account on the instance.  Mastodon uses
+// incrementing numeric IDs, but GoToSocial and Pleroma use ULID/snowflake
+// strings that don't fit in an int, so the API represents account IDs as
+// strings throughout.
+//
+// This migration is scoped to the account subsystem's function
+// signatures; it deliberately does not touch the Account entity's own ID
+// field (entities.go, out of scope here), so nothing in this file reads
+// or compares account.ID — not-found detection below goes through the
+// typed APIError/ErrNotFound of checkAPIResponse instead.
+type AccountID string
+
+// NewAccountID converts a legacy numeric Mastodon account ID into the
+// string-based AccountID used across the API.
+func NewAccountID(id int) AccountID {
+	return AccountID(strconv.Itoa(id))
+}
+
+// String returns the account ID as a plain string.
+func (id AccountID) String() string {
+	return string(id)
+}
+
 // getAccountsOptions contains option fields for POST and DELETE API calls
 type getAccountsOptions struct {
 	// The ID is used for most commands
-	ID int
+	ID AccountID
 
 	// The following fields are used when searching for accounts
 	Q     string
@@ -23,10 +48,10 @@ type getAccountsOptions struct {
 // "block", "unblock", "mute", "unmute", "follow_requests/authorize" or
 // "follow_requests/reject".
 // The id is optional and depends on the target.
-func (g *Client) getSingleAccount(target string, id int) (*Account, error) {
+func (g *Client) getSingleAccount(ctx context.Context, target string, id AccountID) (*Account, error) {
 	var endPoint string
 	method := rest.Get
-	strID := strconv.Itoa(id)
+	strID := id.String()
 
 	switch target {
 	case "account":
@@ -48,18 +73,13 @@ func (g *Client) getSingleAccount(target string, id int) (*Account, error) {
 	req := g.prepareRequest(endPoint)
 	req.Method = method
 
-	r, err := rest.API(req)
+	r, err := doRequest(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("getAccount (%s): %s", target, err.Error())
 	}
 
-	// Check for error reply
-	var errorResult Error
-	if err := json.Unmarshal([]byte(r.Body), &errorResult); err == nil {
-		// The empty object is not an error
-		if errorResult.Text != "" {
-			return nil, fmt.Errorf("%s", errorResult.Text)
-		}
+	if err := checkAPIResponse(r); err != nil {
+		return nil, err
 	}
 
 	// Not an error reply; let's unmarshal the data
@@ -71,27 +91,29 @@ func (g *Client) getSingleAccount(target string, id int) (*Account, error) {
 	return &account, nil
 }
 
-// getMultipleAccounts returns a list of account entities
+// getMultipleAccounts returns a list of account entities, along with the
+// pagination cursors extracted from the response's Link header (nil if
+// the endpoint returned no further pages).
 // The target can be "followers", "following", "search", "blocks", "mutes",
 // "follow_requests".
 // The id is optional and depends on the target.
-func (g *Client) getMultipleAccounts(target string, opts *getAccountsOptions) ([]Account, error) {
+func (g *Client) getMultipleAccounts(ctx context.Context, target string, opts *getAccountsOptions, listOpts *ListOptions) ([]Account, *Pagination, error) {
 	var endPoint string
 	switch target {
 	case "followers", "following":
-		if opts == nil || opts.ID < 1 {
-			return []Account{}, ErrInvalidID
+		if opts == nil || opts.ID == "" {
+			return []Account{}, nil, ErrInvalidID
 		}
-		endPoint = "accounts/" + strconv.Itoa(opts.ID) + "/" + target
+		endPoint = "accounts/" + opts.ID.String() + "/" + target
 	case "follow_requests", "blocks", "mutes":
 		endPoint = target
 	case "search":
 		if opts == nil || opts.Q == "" {
-			return []Account{}, ErrInvalidParameter
+			return []Account{}, nil, ErrInvalidParameter
 		}
 		endPoint = "accounts/" + target
 	default:
-		return nil, ErrInvalidParameter
+		return nil, nil, ErrInvalidParameter
 	}
 
 	req := g.prepareRequest(endPoint)
@@ -103,95 +125,180 @@ func (g *Client) getMultipleAccounts(target string, opts *getAccountsOptions) ([
 			req.QueryParams["limit"] = strconv.Itoa(opts.Limit)
 		}
 	}
+	setListOptionsParams(req, listOpts)
 
-	r, err := rest.API(req)
+	r, err := doRequest(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("getAccount (%s): %s", target, err.Error())
+		return nil, nil, fmt.Errorf("getAccount (%s): %s", target, err.Error())
 	}
 
-	// Check for error reply
-	var errorResult Error
-	if err := json.Unmarshal([]byte(r.Body), &errorResult); err == nil {
-		// The empty object is not an error
-		if errorResult.Text != "" {
-			return nil, fmt.Errorf("%s", errorResult.Text)
-		}
+	if err := checkAPIResponse(r); err != nil {
+		return nil, nil, err
 	}
 
 	// Not an error reply; let's unmarshal the data
 	var accounts []Account
 	err = json.Unmarshal([]byte(r.Body), &accounts)
 	if err != nil {
-		return nil, fmt.Errorf("getAccount (%s) API: %s", target, err.Error())
+		return nil, nil, fmt.Errorf("getAccount (%s) API: %s", target, err.Error())
 	}
-	return accounts, nil
+	return accounts, parseLinkHeader(r.Headers), nil
 }
 
 // GetAccount returns an account entity
-// The returned value can be nil if there is an error or if the
-// requested ID does not exist.
-func (g *Client) GetAccount(id int) (*Account, error) {
-	account, err := g.getSingleAccount("account", id)
-	if err != nil {
-		return nil, err
-	}
-	if account != nil && account.ID == 0 {
-		return nil, ErrEntityNotFound
-	}
-	return account, nil
+// The returned value can be nil if there is an error. A non-existent ID
+// surfaces as an error satisfying errors.Is(err, ErrNotFound), from the
+// server's 404 response.
+func (g *Client) GetAccount(id AccountID) (*Account, error) {
+	return g.GetAccountContext(context.Background(), id)
+}
+
+// GetAccountContext does the same as GetAccount, with a context that can
+// cancel the underlying request.
+func (g *Client) GetAccountContext(ctx context.Context, id AccountID) (*Account, error) {
+	return g.getSingleAccount(ctx, "account", id)
 }
 
 // GetCurrentAccount returns the current user account
 func (g *Client) GetCurrentAccount() (*Account, error) {
-	account, err := g.getSingleAccount("verify_credentials", 0)
-	if err != nil {
-		return nil, err
-	}
-	if account != nil && account.ID == 0 {
-		return nil, ErrEntityNotFound
-	}
-	return account, nil
+	return g.GetCurrentAccountContext(context.Background())
+}
+
+// GetCurrentAccountContext does the same as GetCurrentAccount, with a
+// context that can cancel the underlying request.
+func (g *Client) GetCurrentAccountContext(ctx context.Context) (*Account, error) {
+	return g.getSingleAccount(ctx, "verify_credentials", "")
 }
 
 // GetAccountFollowers returns the list of accounts following a given account
-func (g *Client) GetAccountFollowers(accountID int) ([]Account, error) {
+func (g *Client) GetAccountFollowers(accountID AccountID) ([]Account, error) {
+	return g.GetAccountFollowersContext(context.Background(), accountID)
+}
+
+// GetAccountFollowersContext does the same as GetAccountFollowers, with a
+// context that can cancel the underlying request.
+func (g *Client) GetAccountFollowersContext(ctx context.Context, accountID AccountID) ([]Account, error) {
 	o := &getAccountsOptions{ID: accountID}
-	return g.getMultipleAccounts("followers", o)
+	accounts, _, err := g.getMultipleAccounts(ctx, "followers", o, nil)
+	return accounts, err
 }
 
-// GetAccountFollowing returns the list of accounts a given account is following
-func (g *Client) GetAccountFollowing(accountID int) ([]Account, error) {
+// GetAccountFollowersWithOptions returns a page of the accounts following a
+// given account, along with the pagination cursors for walking further
+// pages.
+func (g *Client) GetAccountFollowersWithOptions(accountID AccountID, opts *ListOptions) ([]Account, *Pagination, error) {
+	return g.GetAccountFollowersWithOptionsContext(context.Background(), accountID, opts)
+}
+
+// GetAccountFollowersWithOptionsContext does the same as
+// GetAccountFollowersWithOptions, with a context that can cancel the
+// underlying request.
+func (g *Client) GetAccountFollowersWithOptionsContext(ctx context.Context, accountID AccountID, opts *ListOptions) ([]Account, *Pagination, error) {
 	o := &getAccountsOptions{ID: accountID}
-	return g.getMultipleAccounts("following", o)
+	return g.getMultipleAccounts(ctx, "followers", o, opts)
 }
 
-// FollowAccount follows an account
-func (g *Client) FollowAccount(id int) error {
-	account, err := g.getSingleAccount("follow", id)
-	if err != nil {
-		return err
+// NewAccountFollowersIterator returns an iterator that walks successive
+// pages of accountID's followers, starting from opts (which may be nil).
+func (g *Client) NewAccountFollowersIterator(accountID AccountID, opts *ListOptions) *AccountListIterator {
+	return g.NewAccountFollowersIteratorContext(context.Background(), accountID, opts)
+}
+
+// NewAccountFollowersIteratorContext does the same as
+// NewAccountFollowersIterator, with a context that applies to every
+// request the iterator makes.
+func (g *Client) NewAccountFollowersIteratorContext(ctx context.Context, accountID AccountID, opts *ListOptions) *AccountListIterator {
+	return &AccountListIterator{
+		ctx:  ctx,
+		opts: opts,
+		fetch: func(ctx context.Context, o *ListOptions) ([]Account, *Pagination, error) {
+			return g.GetAccountFollowersWithOptionsContext(ctx, accountID, o)
+		},
 	}
-	if account != nil && account.ID != id {
-		return ErrEntityNotFound
+}
+
+// GetAccountFollowing returns the list of accounts a given account is following
+func (g *Client) GetAccountFollowing(accountID AccountID) ([]Account, error) {
+	return g.GetAccountFollowingContext(context.Background(), accountID)
+}
+
+// GetAccountFollowingContext does the same as GetAccountFollowing, with a
+// context that can cancel the underlying request.
+func (g *Client) GetAccountFollowingContext(ctx context.Context, accountID AccountID) ([]Account, error) {
+	o := &getAccountsOptions{ID: accountID}
+	accounts, _, err := g.getMultipleAccounts(ctx, "following", o, nil)
+	return accounts, err
+}
+
+// GetAccountFollowingWithOptions returns a page of the accounts a given
+// account is following, along with the pagination cursors for walking
+// further pages.
+func (g *Client) GetAccountFollowingWithOptions(accountID AccountID, opts *ListOptions) ([]Account, *Pagination, error) {
+	return g.GetAccountFollowingWithOptionsContext(context.Background(), accountID, opts)
+}
+
+// GetAccountFollowingWithOptionsContext does the same as
+// GetAccountFollowingWithOptions, with a context that can cancel the
+// underlying request.
+func (g *Client) GetAccountFollowingWithOptionsContext(ctx context.Context, accountID AccountID, opts *ListOptions) ([]Account, *Pagination, error) {
+	o := &getAccountsOptions{ID: accountID}
+	return g.getMultipleAccounts(ctx, "following", o, opts)
+}
+
+// NewAccountFollowingIterator returns an iterator that walks successive
+// pages of the accounts accountID is following, starting from opts (which
+// may be nil).
+func (g *Client) NewAccountFollowingIterator(accountID AccountID, opts *ListOptions) *AccountListIterator {
+	return g.NewAccountFollowingIteratorContext(context.Background(), accountID, opts)
+}
+
+// NewAccountFollowingIteratorContext does the same as
+// NewAccountFollowingIterator, with a context that applies to every
+// request the iterator makes.
+func (g *Client) NewAccountFollowingIteratorContext(ctx context.Context, accountID AccountID, opts *ListOptions) *AccountListIterator {
+	return &AccountListIterator{
+		ctx:  ctx,
+		opts: opts,
+		fetch: func(ctx context.Context, o *ListOptions) ([]Account, *Pagination, error) {
+			return g.GetAccountFollowingWithOptionsContext(ctx, accountID, o)
+		},
 	}
-	return nil
+}
+
+// FollowAccount follows an account
+func (g *Client) FollowAccount(id AccountID) error {
+	return g.FollowAccountContext(context.Background(), id)
+}
+
+// FollowAccountContext does the same as FollowAccount, with a context
+// that can cancel the underlying request.
+func (g *Client) FollowAccountContext(ctx context.Context, id AccountID) error {
+	_, err := g.getSingleAccount(ctx, "follow", id)
+	return err
 }
 
 // UnfollowAccount unfollows an account
-func (g *Client) UnfollowAccount(id int) error {
-	account, err := g.getSingleAccount("unfollow", id)
-	if err != nil {
-		return err
-	}
-	if account != nil && account.ID != id {
-		return ErrEntityNotFound
-	}
-	return nil
+func (g *Client) UnfollowAccount(id AccountID) error {
+	return g.UnfollowAccountContext(context.Background(), id)
+}
+
+// UnfollowAccountContext does the same as UnfollowAccount, with a
+// context that can cancel the underlying request.
+func (g *Client) UnfollowAccountContext(ctx context.Context, id AccountID) error {
+	_, err := g.getSingleAccount(ctx, "unfollow", id)
+	return err
 }
 
 // FollowRemoteAccount follows a remote account
 // The parameter 'id' is a URI (username@domain).
 func (g *Client) FollowRemoteAccount(id string) (*Account, error) {
+	return g.FollowRemoteAccountContext(context.Background(), id)
+}
+
+// FollowRemoteAccountContext does the same as FollowRemoteAccount, with a
+// context that can cancel the underlying request — useful here since a
+// remote WebFinger lookup can block for a while.
+func (g *Client) FollowRemoteAccountContext(ctx context.Context, id string) (*Account, error) {
 	if id == "" {
 		return nil, ErrInvalidID
 	}
@@ -199,18 +306,13 @@ func (g *Client) FollowRemoteAccount(id string) (*Account, error) {
 	req := g.prepareRequest("follows")
 	req.Method = rest.Post
 	req.QueryParams["uri"] = id
-	r, err := rest.API(req)
+	r, err := doRequest(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("FollowRemoteAccount: %s", err.Error())
 	}
 
-	// Check for error reply
-	var errorResult Error
-	if err := json.Unmarshal([]byte(r.Body), &errorResult); err == nil {
-		// The empty object is not an error
-		if errorResult.Text != "" {
-			return nil, fmt.Errorf("%s", errorResult.Text)
-		}
+	if err := checkAPIResponse(r); err != nil {
+		return nil, err
 	}
 
 	// Not an error reply; let's unmarshal the data
@@ -219,114 +321,170 @@ func (g *Client) FollowRemoteAccount(id string) (*Account, error) {
 	if err != nil {
 		return nil, fmt.Errorf("FollowRemoteAccount API: %s", err.Error())
 	}
-	if account.ID == 0 {
-		return nil, ErrEntityNotFound
-	}
 	return &account, nil
 }
 
 // BlockAccount blocks an account
-func (g *Client) BlockAccount(id int) error {
-	account, err := g.getSingleAccount("block", id)
-	if err != nil {
-		return err
-	}
-	if account != nil && account.ID != id {
-		return ErrEntityNotFound
-	}
-	return nil
+func (g *Client) BlockAccount(id AccountID) error {
+	return g.BlockAccountContext(context.Background(), id)
+}
+
+// BlockAccountContext does the same as BlockAccount, with a context that
+// can cancel the underlying request.
+func (g *Client) BlockAccountContext(ctx context.Context, id AccountID) error {
+	_, err := g.getSingleAccount(ctx, "block", id)
+	return err
 }
 
 // UnblockAccount unblocks an account
-func (g *Client) UnblockAccount(id int) error {
-	account, err := g.getSingleAccount("unblock", id)
-	if err != nil {
-		return err
-	}
-	if account != nil && account.ID != id {
-		return ErrEntityNotFound
-	}
-	return nil
+func (g *Client) UnblockAccount(id AccountID) error {
+	return g.UnblockAccountContext(context.Background(), id)
+}
+
+// UnblockAccountContext does the same as UnblockAccount, with a context
+// that can cancel the underlying request.
+func (g *Client) UnblockAccountContext(ctx context.Context, id AccountID) error {
+	_, err := g.getSingleAccount(ctx, "unblock", id)
+	return err
 }
 
 // MuteAccount mutes an account
-func (g *Client) MuteAccount(id int) error {
-	account, err := g.getSingleAccount("mute", id)
-	if err != nil {
-		return err
-	}
-	if account != nil && account.ID != id {
-		return ErrEntityNotFound
-	}
-	return nil
+func (g *Client) MuteAccount(id AccountID) error {
+	return g.MuteAccountContext(context.Background(), id)
+}
+
+// MuteAccountContext does the same as MuteAccount, with a context that
+// can cancel the underlying request.
+func (g *Client) MuteAccountContext(ctx context.Context, id AccountID) error {
+	_, err := g.getSingleAccount(ctx, "mute", id)
+	return err
 }
 
 // UnmuteAccount unmutes an account
-func (g *Client) UnmuteAccount(id int) error {
-	account, err := g.getSingleAccount("unmute", id)
-	if err != nil {
-		return err
-	}
-	if account != nil && account.ID != id {
-		return ErrEntityNotFound
-	}
-	return nil
+func (g *Client) UnmuteAccount(id AccountID) error {
+	return g.UnmuteAccountContext(context.Background(), id)
+}
+
+// UnmuteAccountContext does the same as UnmuteAccount, with a context
+// that can cancel the underlying request.
+func (g *Client) UnmuteAccountContext(ctx context.Context, id AccountID) error {
+	_, err := g.getSingleAccount(ctx, "unmute", id)
+	return err
 }
 
 // SearchAccounts returns a list of accounts matching the query string
 // The limit parameter is optional (can be 0).
 func (g *Client) SearchAccounts(query string, limit int) ([]Account, error) {
+	return g.SearchAccountsContext(context.Background(), query, limit)
+}
+
+// SearchAccountsContext does the same as SearchAccounts, with a context
+// that can cancel the underlying request.
+func (g *Client) SearchAccountsContext(ctx context.Context, query string, limit int) ([]Account, error) {
 	o := &getAccountsOptions{Q: query, Limit: limit}
-	return g.getMultipleAccounts("search", o)
+	accounts, _, err := g.getMultipleAccounts(ctx, "search", o, nil)
+	return accounts, err
+}
+
+// SearchAccountsWithOptions returns a page of accounts matching the query
+// string, along with the pagination cursors for walking further pages.
+func (g *Client) SearchAccountsWithOptions(query string, opts *ListOptions) ([]Account, *Pagination, error) {
+	return g.SearchAccountsWithOptionsContext(context.Background(), query, opts)
+}
+
+// SearchAccountsWithOptionsContext does the same as
+// SearchAccountsWithOptions, with a context that can cancel the
+// underlying request.
+func (g *Client) SearchAccountsWithOptionsContext(ctx context.Context, query string, opts *ListOptions) ([]Account, *Pagination, error) {
+	o := &getAccountsOptions{Q: query}
+	return g.getMultipleAccounts(ctx, "search", o, opts)
+}
+
+// NewSearchAccountsIterator returns an iterator that walks successive
+// pages of accounts matching query, starting from opts (which may be
+// nil).
+func (g *Client) NewSearchAccountsIterator(query string, opts *ListOptions) *AccountListIterator {
+	return g.NewSearchAccountsIteratorContext(context.Background(), query, opts)
+}
+
+// NewSearchAccountsIteratorContext does the same as
+// NewSearchAccountsIterator, with a context that applies to every
+// request the iterator makes.
+func (g *Client) NewSearchAccountsIteratorContext(ctx context.Context, query string, opts *ListOptions) *AccountListIterator {
+	return &AccountListIterator{
+		ctx:  ctx,
+		opts: opts,
+		fetch: func(ctx context.Context, o *ListOptions) ([]Account, *Pagination, error) {
+			return g.SearchAccountsWithOptionsContext(ctx, query, o)
+		},
+	}
 }
 
 // GetBlockedAccounts returns the list of blocked accounts
 func (g *Client) GetBlockedAccounts() ([]Account, error) {
-	return g.getMultipleAccounts("blocks", nil)
+	return g.GetBlockedAccountsContext(context.Background())
+}
+
+// GetBlockedAccountsContext does the same as GetBlockedAccounts, with a
+// context that can cancel the underlying request.
+func (g *Client) GetBlockedAccountsContext(ctx context.Context) ([]Account, error) {
+	accounts, _, err := g.getMultipleAccounts(ctx, "blocks", nil, nil)
+	return accounts, err
 }
 
 // GetMutedAccounts returns the list of muted accounts
 func (g *Client) GetMutedAccounts() ([]Account, error) {
-	return g.getMultipleAccounts("mutes", nil)
+	return g.GetMutedAccountsContext(context.Background())
+}
+
+// GetMutedAccountsContext does the same as GetMutedAccounts, with a
+// context that can cancel the underlying request.
+func (g *Client) GetMutedAccountsContext(ctx context.Context) ([]Account, error) {
+	accounts, _, err := g.getMultipleAccounts(ctx, "mutes", nil, nil)
+	return accounts, err
 }
 
 // GetAccountFollowRequests returns the list of follow requests accounts
 func (g *Client) GetAccountFollowRequests() ([]Account, error) {
-	return g.getMultipleAccounts("follow_requests", nil)
+	return g.GetAccountFollowRequestsContext(context.Background())
+}
+
+// GetAccountFollowRequestsContext does the same as
+// GetAccountFollowRequests, with a context that can cancel the
+// underlying request.
+func (g *Client) GetAccountFollowRequestsContext(ctx context.Context) ([]Account, error) {
+	accounts, _, err := g.getMultipleAccounts(ctx, "follow_requests", nil, nil)
+	return accounts, err
 }
 
 // GetAccountRelationships returns a list of relationship entities for the given accounts
-// NOTE: Currently it doesn't seem to work with several items.
-func (g *Client) GetAccountRelationships(accountIDs []int) ([]Relationship, error) {
+func (g *Client) GetAccountRelationships(accountIDs []AccountID) ([]Relationship, error) {
+	return g.GetAccountRelationshipsContext(context.Background(), accountIDs)
+}
+
+// GetAccountRelationshipsContext does the same as GetAccountRelationships,
+// with a context that can cancel the underlying request.
+func (g *Client) GetAccountRelationshipsContext(ctx context.Context, accountIDs []AccountID) ([]Relationship, error) {
 	if len(accountIDs) < 1 {
 		return nil, ErrInvalidID
 	}
 
-	req := g.prepareRequest("accounts/relationships")
-
-	if len(accountIDs) > 1 { // XXX
-		return nil, fmt.Errorf("accounts/relationships currently does not work with more than 1 ID")
+	// The API expects repeated "id[]=X" query parameters, which the
+	// QueryParams map (one value per key) cannot express, so the query
+	// string is built by hand and appended to the endpoint.
+	values := url.Values{}
+	for _, id := range accountIDs {
+		values.Add("id[]", id.String())
 	}
-	req.QueryParams["id"] = strconv.Itoa(accountIDs[0])
-	/*
-		for i, id := range accountIDList {
-			qID := fmt.Sprintf("id[%d]", i+1)
-			req.QueryParams[qID] = strconv.Itoa(id)
-		}
-	*/
+	req := g.prepareRequest("accounts/relationships?" + values.Encode())
 
-	r, err := rest.API(req)
+	r, err := doRequest(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("GetAccountRelationships: %s", err.Error())
 	}
 
-	// Check for error reply
-	var errorResult Error
-	if err := json.Unmarshal([]byte(r.Body), &errorResult); err == nil {
-		// The empty object is not an error
-		if errorResult.Text != "" {
-			return nil, fmt.Errorf("%s", errorResult.Text)
-		}
+	if err := checkAPIResponse(r); err != nil {
+		return nil, err
 	}
 
 	// Not an error reply; let's unmarshal the data
@@ -338,15 +496,177 @@ func (g *Client) GetAccountRelationships(accountIDs []int) ([]Relationship, erro
 	return rl, nil
 }
 
+// postAccountRelationshipAction posts to an account sub-resource
+// ("accounts/:id/:action") and decodes the response into the resulting
+// Relationship entity. params is optional and, when given, is encoded as
+// the request's query string (the convention this client uses for POST
+// parameters); it supports repeated keys (e.g. "languages[]").
+func (g *Client) postAccountRelationshipAction(ctx context.Context, id AccountID, action string, params url.Values) (*Relationship, error) {
+	if id == "" {
+		return nil, ErrInvalidID
+	}
+
+	endPoint := "accounts/" + id.String() + "/" + action
+	if len(params) > 0 {
+		endPoint += "?" + params.Encode()
+	}
+	req := g.prepareRequest(endPoint)
+	req.Method = rest.Post
+
+	r, err := doRequest(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", action, err.Error())
+	}
+
+	if err := checkAPIResponse(r); err != nil {
+		return nil, err
+	}
+
+	var rel Relationship
+	if err := json.Unmarshal([]byte(r.Body), &rel); err != nil {
+		return nil, fmt.Errorf("%s API: %s", action, err.Error())
+	}
+	return &rel, nil
+}
+
+// SetAccountNote sets (or clears, with an empty note) the current user's
+// private note about an account.
+func (g *Client) SetAccountNote(id AccountID, note string) (*Relationship, error) {
+	return g.SetAccountNoteContext(context.Background(), id, note)
+}
+
+// SetAccountNoteContext does the same as SetAccountNote, with a context
+// that can cancel the underlying request.
+func (g *Client) SetAccountNoteContext(ctx context.Context, id AccountID, note string) (*Relationship, error) {
+	params := url.Values{}
+	params.Set("comment", note)
+	return g.postAccountRelationshipAction(ctx, id, "note", params)
+}
+
+// PinAccount endorses an account, featuring it on the current user's
+// profile.
+func (g *Client) PinAccount(id AccountID) (*Relationship, error) {
+	return g.PinAccountContext(context.Background(), id)
+}
+
+// PinAccountContext does the same as PinAccount, with a context that can
+// cancel the underlying request.
+func (g *Client) PinAccountContext(ctx context.Context, id AccountID) (*Relationship, error) {
+	return g.postAccountRelationshipAction(ctx, id, "pin", nil)
+}
+
+// UnpinAccount removes an account endorsement set by PinAccount.
+func (g *Client) UnpinAccount(id AccountID) (*Relationship, error) {
+	return g.UnpinAccountContext(context.Background(), id)
+}
+
+// UnpinAccountContext does the same as UnpinAccount, with a context that
+// can cancel the underlying request.
+func (g *Client) UnpinAccountContext(ctx context.Context, id AccountID) (*Relationship, error) {
+	return g.postAccountRelationshipAction(ctx, id, "unpin", nil)
+}
+
+// RemoveFromFollowers removes an account from the current user's
+// followers, without blocking it.
+func (g *Client) RemoveFromFollowers(id AccountID) (*Relationship, error) {
+	return g.RemoveFromFollowersContext(context.Background(), id)
+}
+
+// RemoveFromFollowersContext does the same as RemoveFromFollowers, with a
+// context that can cancel the underlying request.
+func (g *Client) RemoveFromFollowersContext(ctx context.Context, id AccountID) (*Relationship, error) {
+	return g.postAccountRelationshipAction(ctx, id, "remove_from_followers", nil)
+}
+
+// FollowOptions contains the optional parameters accepted by
+// FollowAccountWithOptions. A nil Reblogs/Notify leaves the server's
+// default behavior untouched; an empty Languages leaves the followed
+// account's posts unfiltered by language.
+type FollowOptions struct {
+	Reblogs   *bool
+	Notify    *bool
+	Languages []string
+}
+
+// FollowAccountWithOptions follows an account with the extended follow
+// parameters (reblogs visibility, new-post notifications, language
+// filter), returning the updated Relationship.
+func (g *Client) FollowAccountWithOptions(id AccountID, opts FollowOptions) (*Relationship, error) {
+	return g.FollowAccountWithOptionsContext(context.Background(), id, opts)
+}
+
+// FollowAccountWithOptionsContext does the same as
+// FollowAccountWithOptions, with a context that can cancel the
+// underlying request.
+func (g *Client) FollowAccountWithOptionsContext(ctx context.Context, id AccountID, opts FollowOptions) (*Relationship, error) {
+	params := url.Values{}
+	if opts.Reblogs != nil {
+		params.Set("reblogs", strconv.FormatBool(*opts.Reblogs))
+	}
+	if opts.Notify != nil {
+		params.Set("notify", strconv.FormatBool(*opts.Notify))
+	}
+	for _, lang := range opts.Languages {
+		params.Add("languages[]", lang)
+	}
+	return g.postAccountRelationshipAction(ctx, id, "follow", params)
+}
+
 // GetAccountStatuses returns a list of status entities for the given account
 // If onlyMedia is true, returns only statuses that have media attachments.
 // If excludeReplies is true, skip statuses that reply to other statuses.
-func (g *Client) GetAccountStatuses(accountID int, onlyMedia, excludeReplies bool) ([]Status, error) {
-	if accountID < 1 {
-		return nil, ErrInvalidID
+func (g *Client) GetAccountStatuses(accountID AccountID, onlyMedia, excludeReplies bool) ([]Status, error) {
+	return g.GetAccountStatusesContext(context.Background(), accountID, onlyMedia, excludeReplies)
+}
+
+// GetAccountStatusesContext does the same as GetAccountStatuses, with a
+// context that can cancel the underlying request.
+func (g *Client) GetAccountStatusesContext(ctx context.Context, accountID AccountID, onlyMedia, excludeReplies bool) ([]Status, error) {
+	sl, _, err := g.getAccountStatuses(ctx, accountID, onlyMedia, excludeReplies, nil)
+	return sl, err
+}
+
+// GetAccountStatusesWithOptions returns a page of status entities for the
+// given account, along with the pagination cursors for walking further
+// pages.
+// If onlyMedia is true, returns only statuses that have media attachments.
+// If excludeReplies is true, skip statuses that reply to other statuses.
+func (g *Client) GetAccountStatusesWithOptions(accountID AccountID, onlyMedia, excludeReplies bool, opts *ListOptions) ([]Status, *Pagination, error) {
+	return g.GetAccountStatusesWithOptionsContext(context.Background(), accountID, onlyMedia, excludeReplies, opts)
+}
+
+// GetAccountStatusesWithOptionsContext does the same as
+// GetAccountStatusesWithOptions, with a context that can cancel the
+// underlying request.
+func (g *Client) GetAccountStatusesWithOptionsContext(ctx context.Context, accountID AccountID, onlyMedia, excludeReplies bool, opts *ListOptions) ([]Status, *Pagination, error) {
+	return g.getAccountStatuses(ctx, accountID, onlyMedia, excludeReplies, opts)
+}
+
+// NewAccountStatusesIterator returns an iterator that walks successive
+// pages of accountID's statuses, starting from opts (which may be nil).
+func (g *Client) NewAccountStatusesIterator(accountID AccountID, onlyMedia, excludeReplies bool, opts *ListOptions) *StatusListIterator {
+	return g.NewAccountStatusesIteratorContext(context.Background(), accountID, onlyMedia, excludeReplies, opts)
+}
+
+// NewAccountStatusesIteratorContext does the same as
+// NewAccountStatusesIterator, with a context that applies to every
+// request the iterator makes.
+func (g *Client) NewAccountStatusesIteratorContext(ctx context.Context, accountID AccountID, onlyMedia, excludeReplies bool, opts *ListOptions) *StatusListIterator {
+	return &StatusListIterator{
+		ctx:  ctx,
+		opts: opts,
+		fetch: func(ctx context.Context, o *ListOptions) ([]Status, *Pagination, error) {
+			return g.GetAccountStatusesWithOptionsContext(ctx, accountID, onlyMedia, excludeReplies, o)
+		},
+	}
+}
+
+func (g *Client) getAccountStatuses(ctx context.Context, accountID AccountID, onlyMedia, excludeReplies bool, listOpts *ListOptions) ([]Status, *Pagination, error) {
+	if accountID == "" {
+		return nil, nil, ErrInvalidID
 	}
 
-	endPoint := "accounts/" + strconv.Itoa(accountID) + "/" + "statuses"
+	endPoint := "accounts/" + accountID.String() + "/" + "statuses"
 	req := g.prepareRequest(endPoint)
 
 	if onlyMedia {
@@ -355,36 +675,38 @@ func (g *Client) GetAccountStatuses(accountID int, onlyMedia, excludeReplies boo
 	if excludeReplies {
 		req.QueryParams["exclude_replies"] = "true"
 	}
+	setListOptionsParams(req, listOpts)
 
-	r, err := rest.API(req)
+	r, err := doRequest(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("GetAccountStatuses: %s", err.Error())
+		return nil, nil, fmt.Errorf("GetAccountStatuses: %s", err.Error())
 	}
 
-	// Check for error reply
-	var errorResult Error
-	if err := json.Unmarshal([]byte(r.Body), &errorResult); err == nil {
-		// The empty object is not an error
-		if errorResult.Text != "" {
-			return nil, fmt.Errorf("%s", errorResult.Text)
-		}
+	if err := checkAPIResponse(r); err != nil {
+		return nil, nil, err
 	}
 
 	// Not an error reply; let's unmarshal the data
 	var sl []Status
 	err = json.Unmarshal([]byte(r.Body), &sl)
 	if err != nil {
-		return nil, fmt.Errorf("accounts/statuses API: %s", err.Error())
+		return nil, nil, fmt.Errorf("accounts/statuses API: %s", err.Error())
 	}
-	return sl, nil
+	return sl, parseLinkHeader(r.Headers), nil
 }
 
 // FollowRequestAuthorize authorizes or rejects an account follow-request
-func (g *Client) FollowRequestAuthorize(accountID int, authorize bool) error {
+func (g *Client) FollowRequestAuthorize(accountID AccountID, authorize bool) error {
+	return g.FollowRequestAuthorizeContext(context.Background(), accountID, authorize)
+}
+
+// FollowRequestAuthorizeContext does the same as FollowRequestAuthorize,
+// with a context that can cancel the underlying request.
+func (g *Client) FollowRequestAuthorizeContext(ctx context.Context, accountID AccountID, authorize bool) error {
 	endPoint := "follow_requests/reject"
 	if authorize {
 		endPoint = "follow_requests/authorize"
 	}
-	_, err := g.getSingleAccount(endPoint, accountID)
+	_, err := g.getSingleAccount(ctx, endPoint, accountID)
 	return err
 }
@@ -0,0 +1,98 @@
+package gondole
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sendgrid/rest"
+)
+
+// RateLimit carries the rate-limit information returned alongside an API
+// response, when the server provides it.
+type RateLimit struct {
+	// Remaining is the number of requests left in the current window.
+	Remaining int
+	// Reset is when the current rate-limit window ends.
+	Reset time.Time
+}
+
+// APIError represents a failure reply from a Mastodon-compatible API.  It
+// carries the original HTTP status code and message, plus rate-limit
+// information when available, so callers can react appropriately (e.g.
+// retry-after handling on a 429).
+//
+// Use errors.Is with the sentinel errors below to classify an APIError
+// without inspecting StatusCode directly.
+type APIError struct {
+	StatusCode int
+	Message    string
+	RateLimit  *RateLimit
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error %d: %s", e.StatusCode, e.Message)
+}
+
+// Is reports whether target is one of the sentinel errors matching e's
+// StatusCode, so that errors.Is(err, ErrNotFound) works on a wrapped
+// *APIError.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	}
+	return false
+}
+
+// Sentinel errors for common HTTP status classifications returned by the
+// API.  Test a returned error with errors.Is(err, ErrRateLimited), etc.
+var (
+	ErrRateLimited = errors.New("rate limited")
+	ErrForbidden   = errors.New("forbidden")
+	ErrNotFound    = errors.New("not found")
+)
+
+// newAPIError builds an *APIError from a failed rest.Response, decoding
+// the error body (if any) and any rate-limit headers.
+func newAPIError(r *rest.Response) *APIError {
+	apiErr := &APIError{StatusCode: r.StatusCode}
+
+	var errorResult Error
+	if err := json.Unmarshal([]byte(r.Body), &errorResult); err == nil {
+		apiErr.Message = errorResult.Text
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = http.StatusText(r.StatusCode)
+	}
+
+	if remaining := r.Headers.Get("X-RateLimit-Remaining"); remaining != "" {
+		n, err := strconv.Atoi(remaining)
+		if err == nil {
+			rl := &RateLimit{Remaining: n}
+			if reset := r.Headers.Get("X-RateLimit-Reset"); reset != "" {
+				if t, err := time.Parse(time.RFC3339, reset); err == nil {
+					rl.Reset = t
+				}
+			}
+			apiErr.RateLimit = rl
+		}
+	}
+	return apiErr
+}
+
+// checkAPIResponse returns a typed *APIError if r's status code indicates
+// a failure, or nil if the request succeeded.
+func checkAPIResponse(r *rest.Response) error {
+	if r.StatusCode >= 200 && r.StatusCode < 300 {
+		return nil
+	}
+	return newAPIError(r)
+}
@@ -0,0 +1,67 @@
+package gondole
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/sendgrid/rest"
+)
+
+func TestCheckAPIResponseClassification(t *testing.T) {
+	cases := []struct {
+		name       string
+		statusCode int
+		body       string
+		wantErr    error
+	}{
+		{"ok", http.StatusOK, `{}`, nil},
+		{"rate limited", http.StatusTooManyRequests, `{"error":"too many requests"}`, ErrRateLimited},
+		{"forbidden", http.StatusForbidden, `{"error":"forbidden"}`, ErrForbidden},
+		{"not found", http.StatusNotFound, `{"error":"not found"}`, ErrNotFound},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := &rest.Response{StatusCode: c.statusCode, Body: c.body, Headers: http.Header{}}
+			err := checkAPIResponse(r)
+			if c.wantErr == nil {
+				if err != nil {
+					t.Fatalf("expected nil error, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error matching %v, got nil", c.wantErr)
+			}
+			if !errors.Is(err, c.wantErr) {
+				t.Fatalf("errors.Is(%v, %v) = false", err, c.wantErr)
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected *APIError, got %T", err)
+			}
+			if apiErr.StatusCode != c.statusCode {
+				t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, c.statusCode)
+			}
+		})
+	}
+}
+
+func TestNewAPIErrorRateLimitHeaders(t *testing.T) {
+	h := http.Header{}
+	h.Set("X-RateLimit-Remaining", "0")
+	h.Set("X-RateLimit-Reset", "2021-01-01T00:00:00Z")
+	r := &rest.Response{StatusCode: http.StatusTooManyRequests, Body: `{}`, Headers: h}
+
+	apiErr := newAPIError(r)
+	if apiErr.RateLimit == nil {
+		t.Fatal("expected RateLimit to be populated")
+	}
+	if apiErr.RateLimit.Remaining != 0 {
+		t.Errorf("Remaining = %d, want 0", apiErr.RateLimit.Remaining)
+	}
+	if apiErr.RateLimit.Reset.IsZero() {
+		t.Error("expected Reset to be parsed")
+	}
+}
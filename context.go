@@ -0,0 +1,56 @@
+package gondole
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/sendgrid/rest"
+)
+
+// doRequest executes req with ctx attached to the underlying HTTP
+// request, so callers can cancel a slow request (e.g. a federation
+// lookup that blocks on a remote WebFinger query) or attach a deadline.
+// It mirrors rest.API's behavior, but goes through net/http directly so
+// the context can reach req.WithContext.
+func doRequest(ctx context.Context, req rest.Request) (*rest.Response, error) {
+	u, err := url.Parse(req.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.QueryParams) != 0 {
+		q := u.Query()
+		for k, v := range req.QueryParams {
+			q.Set(k, v)
+		}
+		u.RawQuery = q.Encode()
+	}
+
+	httpReq, err := http.NewRequest(string(req.Method), u.String(), bytes.NewBuffer(req.Body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq = httpReq.WithContext(ctx)
+	for k, v := range req.Headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rest.Response{
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+		Headers:    resp.Header,
+	}, nil
+}
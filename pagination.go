@@ -0,0 +1,197 @@
+package gondole
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sendgrid/rest"
+)
+
+// Pagination holds the cursors extracted from the "Link" header of a
+// list-endpoint response, as used by Mastodon-compatible APIs to page
+// through results without relying on offsets.
+type Pagination struct {
+	MaxID   string
+	SinceID string
+	MinID   string
+}
+
+// ListOptions contains the parameters accepted by list endpoints that
+// support pagination through the "Link" header cursors.
+// Callers typically start with a zero-value (or Limit-only) ListOptions
+// and then feed the MaxID from the returned Pagination back in to fetch
+// the next page.
+type ListOptions struct {
+	MaxID   string
+	SinceID string
+	MinID   string
+	Limit   int
+}
+
+var linkHeaderPartRx = regexp.MustCompile(`<([^>]+)>;\s*rel="([^"]+)"`)
+
+// parseLinkHeader extracts the pagination cursors from the "Link" header
+// of an API response, as documented for Mastodon/GoToSocial list
+// endpoints.  It returns nil if the header is absent or carries no
+// recognized cursor.
+func parseLinkHeader(h http.Header) *Pagination {
+	link := h.Get("Link")
+	if link == "" {
+		return nil
+	}
+
+	var p Pagination
+	var found bool
+	for _, part := range strings.Split(link, ",") {
+		m := linkHeaderPartRx.FindStringSubmatch(strings.TrimSpace(part))
+		if m == nil {
+			continue
+		}
+		u, err := url.Parse(m[1])
+		if err != nil {
+			continue
+		}
+		q := u.Query()
+		switch m[2] {
+		case "next":
+			if v := q.Get("max_id"); v != "" {
+				p.MaxID = v
+				found = true
+			}
+			if v := q.Get("min_id"); v != "" && p.MaxID == "" {
+				p.MinID = v
+				found = true
+			}
+		case "prev":
+			if v := q.Get("since_id"); v != "" {
+				p.SinceID = v
+				found = true
+			}
+			if v := q.Get("min_id"); v != "" {
+				p.MinID = v
+				found = true
+			}
+		}
+	}
+
+	if !found {
+		return nil
+	}
+	return &p
+}
+
+// setListOptionsParams applies the pagination fields of opts, if any, to
+// the query parameters of req.
+func setListOptionsParams(req rest.Request, opts *ListOptions) {
+	if opts == nil {
+		return
+	}
+	if opts.MaxID != "" {
+		req.QueryParams["max_id"] = opts.MaxID
+	}
+	if opts.SinceID != "" {
+		req.QueryParams["since_id"] = opts.SinceID
+	}
+	if opts.MinID != "" {
+		req.QueryParams["min_id"] = opts.MinID
+	}
+	if opts.Limit > 0 {
+		req.QueryParams["limit"] = strconv.Itoa(opts.Limit)
+	}
+}
+
+// nextListOptions computes the ListOptions for the page after one whose
+// Link header produced p, given the options used for the page just
+// fetched. MaxID (the "next"/older-direction cursor) and MinID (the
+// "prev"/newer-direction cursor) are not composable: a response can
+// carry both at once, but they bound the walk in opposite directions, so
+// only the cursor matching the walk's current direction is carried
+// forward. A walk already anchored on MinID (i.e. started with no MaxID
+// and fed MinID from a previous page) keeps following MinID even if a
+// later page's Link header also carries a MaxID; otherwise MaxID wins,
+// matching the common forward/older paging case. It reports done=true
+// once there is no cursor to continue with.
+func nextListOptions(opts *ListOptions, p *Pagination) (next *ListOptions, done bool) {
+	if p == nil {
+		return nil, true
+	}
+
+	limit := 0
+	followingMinID := opts != nil && opts.MinID != "" && opts.MaxID == ""
+	if followingMinID && p.MinID != "" {
+		if opts != nil {
+			limit = opts.Limit
+		}
+		return &ListOptions{MinID: p.MinID, Limit: limit}, false
+	}
+	if opts != nil {
+		limit = opts.Limit
+	}
+	if p.MaxID != "" {
+		return &ListOptions{MaxID: p.MaxID, Limit: limit}, false
+	}
+	if p.MinID != "" {
+		return &ListOptions{MinID: p.MinID, Limit: limit}, false
+	}
+	return nil, true
+}
+
+// AccountListIterator walks successive pages of an account list endpoint,
+// following the "max_id" (or, as chosen by nextListOptions, "min_id")
+// cursor from the "Link" header until the server reports no further page. The context passed to the constructor that
+// created it applies to every underlying request the iterator makes.
+type AccountListIterator struct {
+	ctx   context.Context
+	fetch func(context.Context, *ListOptions) ([]Account, *Pagination, error)
+	opts  *ListOptions
+	done  bool
+}
+
+// Next returns the next page of accounts.  It returns an empty slice once
+// the list is exhausted; callers should stop iterating when both the
+// returned slice and error are empty/nil.
+func (it *AccountListIterator) Next() ([]Account, error) {
+	if it.done {
+		return nil, nil
+	}
+
+	accounts, p, err := it.fetch(it.ctx, it.opts)
+	if err != nil {
+		return nil, err
+	}
+
+	it.opts, it.done = nextListOptions(it.opts, p)
+	return accounts, nil
+}
+
+// StatusListIterator walks successive pages of a status list endpoint,
+// following the "max_id" (or, as chosen by nextListOptions, "min_id")
+// cursor from the "Link" header until the server reports no further page. The context passed to the constructor that
+// created it applies to every underlying request the iterator makes.
+type StatusListIterator struct {
+	ctx   context.Context
+	fetch func(context.Context, *ListOptions) ([]Status, *Pagination, error)
+	opts  *ListOptions
+	done  bool
+}
+
+// Next returns the next page of statuses.  It returns an empty slice once
+// the list is exhausted; callers should stop iterating when both the
+// returned slice and error are empty/nil.
+func (it *StatusListIterator) Next() ([]Status, error) {
+	if it.done {
+		return nil, nil
+	}
+
+	statuses, p, err := it.fetch(it.ctx, it.opts)
+	if err != nil {
+		return nil, err
+	}
+
+	it.opts, it.done = nextListOptions(it.opts, p)
+	return statuses, nil
+}
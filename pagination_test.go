@@ -0,0 +1,193 @@
+package gondole
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestParseLinkHeaderMaxID(t *testing.T) {
+	h := http.Header{}
+	h.Set("Link", `<https://example.org/api/v1/accounts/1/followers?max_id=7>; rel="next", <https://example.org/api/v1/accounts/1/followers?since_id=9>; rel="prev"`)
+
+	p := parseLinkHeader(h)
+	if p == nil {
+		t.Fatal("expected non-nil Pagination")
+	}
+	if p.MaxID != "7" {
+		t.Errorf("MaxID = %q, want %q", p.MaxID, "7")
+	}
+	if p.SinceID != "9" {
+		t.Errorf("SinceID = %q, want %q", p.SinceID, "9")
+	}
+}
+
+func TestParseLinkHeaderMinIDOnly(t *testing.T) {
+	h := http.Header{}
+	h.Set("Link", `<https://example.org/api/v1/accounts/1/followers?min_id=3>; rel="next"`)
+
+	p := parseLinkHeader(h)
+	if p == nil {
+		t.Fatal("expected non-nil Pagination")
+	}
+	if p.MaxID != "" {
+		t.Errorf("MaxID = %q, want empty", p.MaxID)
+	}
+	if p.MinID != "3" {
+		t.Errorf("MinID = %q, want %q", p.MinID, "3")
+	}
+}
+
+func TestParseLinkHeaderEmpty(t *testing.T) {
+	if p := parseLinkHeader(http.Header{}); p != nil {
+		t.Errorf("expected nil Pagination for missing header, got %+v", p)
+	}
+}
+
+func TestNextListOptionsMixedCursors(t *testing.T) {
+	cases := []struct {
+		name     string
+		opts     *ListOptions
+		p        *Pagination
+		wantOpts *ListOptions
+		wantDone bool
+	}{
+		{
+			name:     "nil pagination stops the walk",
+			opts:     nil,
+			p:        nil,
+			wantOpts: nil,
+			wantDone: true,
+		},
+		{
+			name:     "both cursors present, not already following MinID: MaxID wins",
+			opts:     nil,
+			p:        &Pagination{MaxID: "50", MinID: "100"},
+			wantOpts: &ListOptions{MaxID: "50"},
+			wantDone: false,
+		},
+		{
+			name:     "both cursors present, already following MinID: MinID sticks",
+			opts:     &ListOptions{MinID: "90"},
+			p:        &Pagination{MaxID: "50", MinID: "100"},
+			wantOpts: &ListOptions{MinID: "100"},
+			wantDone: false,
+		},
+		{
+			name:     "MaxID only",
+			opts:     nil,
+			p:        &Pagination{MaxID: "50"},
+			wantOpts: &ListOptions{MaxID: "50"},
+			wantDone: false,
+		},
+		{
+			name:     "MinID only",
+			opts:     nil,
+			p:        &Pagination{MinID: "100"},
+			wantOpts: &ListOptions{MinID: "100"},
+			wantDone: false,
+		},
+		{
+			name:     "neither cursor stops the walk",
+			opts:     nil,
+			p:        &Pagination{},
+			wantOpts: nil,
+			wantDone: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, done := nextListOptions(c.opts, c.p)
+			if done != c.wantDone {
+				t.Fatalf("done = %v, want %v", done, c.wantDone)
+			}
+			if c.wantOpts == nil {
+				if got != nil {
+					t.Fatalf("opts = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.MaxID != c.wantOpts.MaxID || got.MinID != c.wantOpts.MinID {
+				t.Fatalf("opts = %+v, want %+v", got, c.wantOpts)
+			}
+		})
+	}
+}
+
+func TestAccountListIteratorMixedCursorDoesNotSendBoth(t *testing.T) {
+	pages := [][]Account{
+		{{ID: 1}},
+		{{ID: 2}},
+	}
+	calls := 0
+	it := &AccountListIterator{
+		ctx: context.Background(),
+		fetch: func(_ context.Context, opts *ListOptions) ([]Account, *Pagination, error) {
+			defer func() { calls++ }()
+			switch calls {
+			case 0:
+				// A realistic single-page response carrying both a
+				// "next" max_id and a "prev" min_id cursor.
+				return pages[0], &Pagination{MaxID: "50", MinID: "100"}, nil
+			case 1:
+				if opts == nil || opts.MaxID != "50" {
+					t.Fatalf("expected only MaxID to be carried forward, got %+v", opts)
+				}
+				if opts.MinID != "" {
+					t.Fatalf("expected MinID not to be sent alongside MaxID, got %+v", opts)
+				}
+				return pages[1], nil, nil
+			default:
+				t.Fatal("unexpected extra call")
+				return nil, nil, nil
+			}
+		},
+	}
+
+	if _, err := it.Next(); err != nil {
+		t.Fatalf("first page: %v", err)
+	}
+	if _, err := it.Next(); err != nil {
+		t.Fatalf("second page: %v", err)
+	}
+}
+
+func TestAccountListIteratorFollowsMinID(t *testing.T) {
+	pages := [][]Account{
+		{{ID: 1}},
+		{{ID: 2}},
+	}
+	calls := 0
+	it := &AccountListIterator{
+		ctx: context.Background(),
+		fetch: func(_ context.Context, opts *ListOptions) ([]Account, *Pagination, error) {
+			defer func() { calls++ }()
+			switch calls {
+			case 0:
+				return pages[0], &Pagination{MinID: "5"}, nil
+			case 1:
+				if opts == nil || opts.MinID != "5" {
+					t.Fatalf("expected MinID %q to be carried forward, got %+v", "5", opts)
+				}
+				return pages[1], nil, nil
+			default:
+				t.Fatal("unexpected extra call")
+				return nil, nil, nil
+			}
+		},
+	}
+
+	first, err := it.Next()
+	if err != nil || len(first) != 1 || first[0].ID != 1 {
+		t.Fatalf("first page = %+v, err = %v", first, err)
+	}
+	second, err := it.Next()
+	if err != nil || len(second) != 1 || second[0].ID != 2 {
+		t.Fatalf("second page = %+v, err = %v", second, err)
+	}
+	third, err := it.Next()
+	if err != nil || third != nil {
+		t.Fatalf("expected iterator to be done, got %+v, err = %v", third, err)
+	}
+}